@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ChunkStrategy controls how a large staged diff is split before being
+// summarized, to keep each AI call under a manageable size.
+type ChunkStrategy string
+
+const (
+	// ChunkStrategyNone sends the whole diff in a single call, regardless of size.
+	ChunkStrategyNone ChunkStrategy = "none"
+	// ChunkStrategyFile batches whole per-file diffs together under the byte budget.
+	ChunkStrategyFile ChunkStrategy = "file"
+	// ChunkStrategyHunk further splits every file's diff into individual hunks
+	// before batching, so a file whose diff alone exceeds the byte budget is
+	// still divided.
+	ChunkStrategyHunk ChunkStrategy = "hunk"
+)
+
+// mapPromptTemplate asks for a short bullet-list summary of a single diff
+// chunk (the "map" pass) — not a commit message, since the chunk is only
+// part of the overall change.
+const mapPromptTemplate = "Summarize the following diff as a short bullet list of the concrete changes it makes. Do not format it as a commit message, just the bullet points, with no preamble.\n\n---\n%s\n---"
+
+// reducePromptTemplate combines the per-chunk bullet summaries produced by
+// mapPromptTemplate into one conventional-commit message (the "reduce" pass).
+const reducePromptTemplate = "Combine the following change summaries into a single commit message in the Conventional Commits format (type: concise summary, followed by a bullet list of the concrete changes). Output only the commit message, with no preamble or explanation.\n\n---\n%s\n---"
+
+// GenerateCommitMessageChunked summarizes diff via executor, splitting it
+// first when it exceeds maxDiffBytes. Diffs over the budget are split
+// according to strategy, summarized per chunk via a raw Complete call (the
+// "map" pass), and the resulting bullet summaries are combined into a single
+// conventional-commit message with one more raw Complete call (the "reduce"
+// pass) — both passes use their own purpose-built prompts rather than
+// GenerateCommitMessage's diff-shaped template, since neither a bullet
+// summary nor a concatenation of several is itself a diff.
+func GenerateCommitMessageChunked(ctx context.Context, executor AIExecutor, diff string, maxDiffBytes int, strategy ChunkStrategy) (string, error) {
+	if strategy == ChunkStrategyNone || len(diff) <= maxDiffBytes {
+		return executor.GenerateCommitMessage(ctx, diff)
+	}
+
+	files := splitDiffByFile(diff)
+
+	var parts []string
+	if strategy == ChunkStrategyHunk {
+		for _, file := range files {
+			parts = append(parts, splitFileIntoHunks(file)...)
+		}
+	} else {
+		parts = files
+	}
+
+	chunks := batchByByteBudget(parts, maxDiffBytes)
+
+	summaries := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		summary, err := executor.Complete(ctx, fmt.Sprintf(mapPromptTemplate, chunk))
+		if err != nil {
+			return "", fmt.Errorf("failed to summarize diff chunk: %w", err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	combined := strings.Join(summaries, "\n\n")
+	final, err := executor.Complete(ctx, fmt.Sprintf(reducePromptTemplate, combined))
+	if err != nil {
+		return "", fmt.Errorf("failed to combine diff chunk summaries: %w", err)
+	}
+	return final, nil
+}
+
+// splitDiffByFile splits a unified diff into one chunk per "diff --git a/...
+// b/..." section.
+func splitDiffByFile(diff string) []string {
+	lines := strings.Split(diff, "\n")
+
+	var files []string
+	var current []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "diff --git ") && len(current) > 0 {
+			files = append(files, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		files = append(files, strings.Join(current, "\n"))
+	}
+	return files
+}
+
+// splitFileIntoHunks splits a single file's diff into one chunk per "@@"
+// hunk, each chunk keeping the file's header (the "diff --git"/"---"/"+++"
+// lines) so it still reads as a standalone diff.
+func splitFileIntoHunks(fileDiff string) []string {
+	lines := strings.Split(fileDiff, "\n")
+
+	headerEnd := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			headerEnd = i
+			break
+		}
+	}
+	if headerEnd == len(lines) {
+		return []string{fileDiff}
+	}
+	header := strings.Join(lines[:headerEnd], "\n")
+
+	var hunks []string
+	var current []string
+	for _, line := range lines[headerEnd:] {
+		if strings.HasPrefix(line, "@@") && len(current) > 0 {
+			hunks = append(hunks, header+"\n"+strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		hunks = append(hunks, header+"\n"+strings.Join(current, "\n"))
+	}
+	return hunks
+}
+
+// batchByByteBudget greedily groups parts together, each batch staying under
+// maxBytes where possible. A single part larger than maxBytes gets its own
+// batch rather than being dropped.
+func batchByByteBudget(parts []string, maxBytes int) []string {
+	var batches []string
+	var current strings.Builder
+
+	for _, part := range parts {
+		if current.Len() > 0 && current.Len()+1+len(part) > maxBytes {
+			batches = append(batches, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n")
+		}
+		current.WriteString(part)
+	}
+	if current.Len() > 0 {
+		batches = append(batches, current.String())
+	}
+	return batches
+}
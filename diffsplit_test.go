@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recordingExecutor is a mock AIExecutor that returns a canned per-call
+// response numbered by call and counts its calls, so tests can assert the
+// map/reduce call order.
+type recordingExecutor struct {
+	calls int
+}
+
+func (r *recordingExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	r.calls++
+	return fmt.Sprintf("summary-%d: %s", r.calls, strings.Split(diff, "\n")[0]), nil
+}
+
+// Complete mirrors GenerateCommitMessage so tests written against the
+// chunked map/reduce pass can assert call count and order the same way.
+func (r *recordingExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	r.calls++
+	return fmt.Sprintf("summary-%d: %s", r.calls, strings.Split(prompt, "\n")[0]), nil
+}
+
+func syntheticMultiFileDiff() string {
+	return strings.Join([]string{
+		"diff --git a/foo.go b/foo.go",
+		"--- a/foo.go",
+		"+++ b/foo.go",
+		"@@ -1,2 +1,2 @@",
+		"-old foo",
+		"+new foo",
+		"diff --git a/bar.go b/bar.go",
+		"--- a/bar.go",
+		"+++ b/bar.go",
+		"@@ -1,2 +1,2 @@",
+		"-old bar",
+		"+new bar",
+		"diff --git a/baz.go b/baz.go",
+		"--- a/baz.go",
+		"+++ b/baz.go",
+		"@@ -1,2 +1,2 @@",
+		"-old baz",
+		"+new baz",
+	}, "\n")
+}
+
+func TestGenerateCommitMessageChunked_SmallDiffSkipsChunking(t *testing.T) {
+	executor := &recordingExecutor{}
+
+	message, err := GenerateCommitMessageChunked(context.Background(), executor, "diff --git a/foo b/foo", 8000, ChunkStrategyFile)
+	if err != nil {
+		t.Fatalf("GenerateCommitMessageChunked() unexpected error = %v", err)
+	}
+	if executor.calls != 1 {
+		t.Errorf("GenerateCommitMessageChunked() made %d calls for a small diff, want 1", executor.calls)
+	}
+	if message == "" {
+		t.Error("GenerateCommitMessageChunked() returned empty message")
+	}
+}
+
+func TestGenerateCommitMessageChunked_NoneStrategyNeverSplits(t *testing.T) {
+	executor := &recordingExecutor{}
+	diff := syntheticMultiFileDiff()
+
+	if _, err := GenerateCommitMessageChunked(context.Background(), executor, diff, 10, ChunkStrategyNone); err != nil {
+		t.Fatalf("GenerateCommitMessageChunked() unexpected error = %v", err)
+	}
+	if executor.calls != 1 {
+		t.Errorf("GenerateCommitMessageChunked() made %d calls with ChunkStrategyNone, want 1", executor.calls)
+	}
+}
+
+func TestGenerateCommitMessageChunked_MapReducesPerFile(t *testing.T) {
+	executor := &recordingExecutor{}
+	diff := syntheticMultiFileDiff()
+
+	// A tiny budget forces every file into its own map-pass chunk, followed
+	// by one reduce-pass call that combines the three summaries.
+	message, err := GenerateCommitMessageChunked(context.Background(), executor, diff, 10, ChunkStrategyFile)
+	if err != nil {
+		t.Fatalf("GenerateCommitMessageChunked() unexpected error = %v", err)
+	}
+	if executor.calls != 4 {
+		t.Fatalf("GenerateCommitMessageChunked() made %d calls, want 4 (3 map + 1 reduce)", executor.calls)
+	}
+	if !strings.HasPrefix(message, "summary-4:") {
+		t.Errorf("GenerateCommitMessageChunked() final message = %q, want it to come from the 4th (reduce) call", message)
+	}
+}
+
+func TestGenerateCommitMessageChunked_HunkStrategySplitsWithinFile(t *testing.T) {
+	executor := &recordingExecutor{}
+	diff := strings.Join([]string{
+		"diff --git a/foo.go b/foo.go",
+		"--- a/foo.go",
+		"+++ b/foo.go",
+		"@@ -1,2 +1,2 @@",
+		"-old foo 1",
+		"+new foo 1",
+		"@@ -10,2 +10,2 @@",
+		"-old foo 2",
+		"+new foo 2",
+	}, "\n")
+
+	if _, err := GenerateCommitMessageChunked(context.Background(), executor, diff, 10, ChunkStrategyHunk); err != nil {
+		t.Fatalf("GenerateCommitMessageChunked() unexpected error = %v", err)
+	}
+	// 2 hunks summarized individually, plus 1 reduce call.
+	if executor.calls != 3 {
+		t.Errorf("GenerateCommitMessageChunked() made %d calls, want 3 (2 map + 1 reduce)", executor.calls)
+	}
+}
+
+// methodTrackingExecutor records which AIExecutor method was called, so
+// tests can prove the chunked map/reduce pass never double-wraps its
+// purpose-built prompts through GenerateCommitMessage's diff template.
+type methodTrackingExecutor struct {
+	generateCalls int
+	completeCalls int
+}
+
+func (e *methodTrackingExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	e.generateCalls++
+	return "feat: stub", nil
+}
+
+func (e *methodTrackingExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	e.completeCalls++
+	return "- stub bullet", nil
+}
+
+func TestGenerateCommitMessageChunked_MapAndReduceUseCompleteNotGenerateCommitMessage(t *testing.T) {
+	executor := &methodTrackingExecutor{}
+	diff := syntheticMultiFileDiff()
+
+	if _, err := GenerateCommitMessageChunked(context.Background(), executor, diff, 10, ChunkStrategyFile); err != nil {
+		t.Fatalf("GenerateCommitMessageChunked() unexpected error = %v", err)
+	}
+	if executor.generateCalls != 0 {
+		t.Errorf("GenerateCommitMessageChunked() called GenerateCommitMessage %d times for chunked input, want 0 (map/reduce must use Complete)", executor.generateCalls)
+	}
+	if executor.completeCalls != 4 {
+		t.Errorf("GenerateCommitMessageChunked() called Complete %d times, want 4 (3 map + 1 reduce)", executor.completeCalls)
+	}
+}
+
+func TestGenerateCommitMessageChunked_SmallDiffUsesGenerateCommitMessage(t *testing.T) {
+	executor := &methodTrackingExecutor{}
+
+	if _, err := GenerateCommitMessageChunked(context.Background(), executor, "diff --git a/foo b/foo", 8000, ChunkStrategyFile); err != nil {
+		t.Fatalf("GenerateCommitMessageChunked() unexpected error = %v", err)
+	}
+	if executor.generateCalls != 1 || executor.completeCalls != 0 {
+		t.Errorf("GenerateCommitMessageChunked() generateCalls=%d completeCalls=%d, want 1/0 for an unchunked diff", executor.generateCalls, executor.completeCalls)
+	}
+}
+
+func TestSplitDiffByFile(t *testing.T) {
+	files := splitDiffByFile(syntheticMultiFileDiff())
+	if len(files) != 3 {
+		t.Fatalf("splitDiffByFile() returned %d chunks, want 3", len(files))
+	}
+	for i, want := range []string{"foo.go", "bar.go", "baz.go"} {
+		if !strings.Contains(files[i], want) {
+			t.Errorf("splitDiffByFile()[%d] = %q, want it to contain %q", i, files[i], want)
+		}
+	}
+}
+
+func TestBatchByByteBudget(t *testing.T) {
+	parts := []string{"aaaa", "bbbb", "cccc"}
+
+	batches := batchByByteBudget(parts, 9)
+	if len(batches) != 2 {
+		t.Fatalf("batchByByteBudget() returned %d batches, want 2: %v", len(batches), batches)
+	}
+	if batches[0] != "aaaa\nbbbb" {
+		t.Errorf("batchByByteBudget()[0] = %q, want %q", batches[0], "aaaa\nbbbb")
+	}
+	if batches[1] != "cccc" {
+		t.Errorf("batchByByteBudget()[1] = %q, want %q", batches[1], "cccc")
+	}
+}
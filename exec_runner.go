@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+var execCommandContext = exec.CommandContext
+
+// ProgressFunc receives each line of output as it streams in, so callers can
+// show the model "typing" in real time instead of waiting for it to finish.
+type ProgressFunc func(line string)
+
+// ExecErrorKind classifies why a streamed command failed.
+type ExecErrorKind int
+
+const (
+	// ExecErrorUnknown covers a failure that doesn't fit the other kinds
+	// (e.g. the command couldn't be started at all).
+	ExecErrorUnknown ExecErrorKind = iota
+	// ExecErrorTimeout means the command was killed after exceeding its deadline.
+	ExecErrorTimeout
+	// ExecErrorCancelled means the command was killed because its context was cancelled (e.g. SIGINT).
+	ExecErrorCancelled
+	// ExecErrorNonZeroExit means the command ran to completion but exited non-zero.
+	ExecErrorNonZeroExit
+	// ExecErrorMaxOutputExceeded means the command was killed because it
+	// produced more than maxOutputBytes of stdout or stderr.
+	ExecErrorMaxOutputExceeded
+)
+
+// ExecError reports why a streamed command failed, carrying the underlying
+// error, the command's stderr, and a classification of the cause.
+type ExecError struct {
+	Kind   ExecErrorKind
+	Err    error
+	Stderr string
+}
+
+// Error implements the error interface.
+func (e *ExecError) Error() string {
+	switch e.Kind {
+	case ExecErrorTimeout:
+		return fmt.Sprintf("command timed out: %v", e.Err)
+	case ExecErrorCancelled:
+		return fmt.Sprintf("command cancelled: %v", e.Err)
+	case ExecErrorNonZeroExit:
+		return fmt.Sprintf("command exited with error: %v: %s", e.Err, e.Stderr)
+	case ExecErrorMaxOutputExceeded:
+		return fmt.Sprintf("command output exceeded max-output-bytes: %v", e.Err)
+	default:
+		return fmt.Sprintf("command failed: %v", e.Err)
+	}
+}
+
+// Unwrap exposes the underlying error for errors.Is/errors.As.
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// errMaxOutputExceeded is returned by streamLines once maxBytes is hit.
+var errMaxOutputExceeded = errors.New("output exceeded max-output-bytes")
+
+// runStreaming runs name with args to completion, feeding each line of
+// stdout to onProgress as it arrives and returning the full stdout. It
+// enforces maxOutputBytes on stdout and on stderr, each counted separately
+// (0 means unlimited), and classifies failures as an *ExecError.
+func runStreaming(ctx context.Context, name string, args []string, maxOutputBytes int, onProgress ProgressFunc) (string, error) {
+	// runCtx governs only the child process: cancelling it kills the process
+	// immediately once either stream exceeds maxOutputBytes, instead of
+	// leaving a stuck process to run until the caller's ctx (e.g. -timeout)
+	// eventually expires.
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	cmd := execCommandContext(runCtx, name, args...)
+
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", &ExecError{Kind: ExecErrorUnknown, Err: err}
+	}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return "", &ExecError{Kind: ExecErrorUnknown, Err: err}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return "", &ExecError{Kind: ExecErrorUnknown, Err: err}
+	}
+
+	var stdout, stderr strings.Builder
+	var readErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		err := streamLines(stdoutPipe, maxOutputBytes, func(line string) {
+			stdout.WriteString(line)
+			stdout.WriteString("\n")
+			if onProgress != nil {
+				onProgress(line)
+			}
+		})
+		if err != nil {
+			mu.Lock()
+			readErr = err
+			mu.Unlock()
+			cancelRun()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		err := streamLines(stderrPipe, maxOutputBytes, func(line string) {
+			stderr.WriteString(line)
+			stderr.WriteString("\n")
+		})
+		if errors.Is(err, errMaxOutputExceeded) {
+			mu.Lock()
+			if readErr == nil {
+				readErr = err
+			}
+			mu.Unlock()
+			cancelRun()
+		}
+	}()
+
+	wg.Wait()
+	waitErr := cmd.Wait()
+	output := strings.TrimSuffix(stdout.String(), "\n")
+
+	if errors.Is(readErr, errMaxOutputExceeded) {
+		return output, &ExecError{Kind: ExecErrorMaxOutputExceeded, Err: readErr, Stderr: stderr.String()}
+	}
+	if readErr != nil {
+		return output, &ExecError{Kind: ExecErrorUnknown, Err: readErr, Stderr: stderr.String()}
+	}
+
+	if waitErr != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return output, &ExecError{Kind: ExecErrorTimeout, Err: waitErr, Stderr: stderr.String()}
+		case errors.Is(ctx.Err(), context.Canceled):
+			return output, &ExecError{Kind: ExecErrorCancelled, Err: waitErr, Stderr: stderr.String()}
+		default:
+			return output, &ExecError{Kind: ExecErrorNonZeroExit, Err: waitErr, Stderr: stderr.String()}
+		}
+	}
+
+	return output, nil
+}
+
+// streamLines reads lines from r, invoking onLine for each, and stops once
+// more than maxBytes have been read (maxBytes <= 0 means unlimited).
+func streamLines(r io.Reader, maxBytes int, onLine func(line string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	total := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		total += len(line) + 1
+		onLine(line)
+		if maxBytes > 0 && total > maxBytes {
+			return errMaxOutputExceeded
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunStreaming_CollectsOutputAndProgress(t *testing.T) {
+	var lines []string
+	output, err := runStreaming(context.Background(), "printf", []string{"line1\nline2\n"}, 0, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatalf("runStreaming() unexpected error = %v", err)
+	}
+	if output != "line1\nline2" {
+		t.Errorf("runStreaming() output = %q, want %q", output, "line1\nline2")
+	}
+	if len(lines) != 2 || lines[0] != "line1" || lines[1] != "line2" {
+		t.Errorf("runStreaming() progress lines = %v, want [line1 line2]", lines)
+	}
+}
+
+func TestRunStreaming_NonZeroExit(t *testing.T) {
+	_, err := runStreaming(context.Background(), "sh", []string{"-c", "echo boom >&2; exit 3"}, 0, nil)
+	if err == nil {
+		t.Fatal("runStreaming() expected error for non-zero exit, got none")
+	}
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("runStreaming() error = %v, want *ExecError", err)
+	}
+	if execErr.Kind != ExecErrorNonZeroExit {
+		t.Errorf("runStreaming() error kind = %v, want ExecErrorNonZeroExit", execErr.Kind)
+	}
+	if !strings.Contains(execErr.Stderr, "boom") {
+		t.Errorf("runStreaming() error stderr = %q, want it to contain %q", execErr.Stderr, "boom")
+	}
+}
+
+func TestRunStreaming_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := runStreaming(ctx, "sleep", []string{"1"}, 0, nil)
+	if err == nil {
+		t.Fatal("runStreaming() expected timeout error, got none")
+	}
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("runStreaming() error = %v, want *ExecError", err)
+	}
+	if execErr.Kind != ExecErrorTimeout {
+		t.Errorf("runStreaming() error kind = %v, want ExecErrorTimeout", execErr.Kind)
+	}
+}
+
+func TestRunStreaming_MaxOutputBytes(t *testing.T) {
+	_, err := runStreaming(context.Background(), "printf", []string{"a very long line that exceeds the budget\n"}, 10, nil)
+	if err == nil {
+		t.Fatal("runStreaming() expected an error once max-output-bytes was exceeded, got none")
+	}
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("runStreaming() error = %v, want *ExecError", err)
+	}
+	if execErr.Kind != ExecErrorMaxOutputExceeded {
+		t.Errorf("runStreaming() error kind = %v, want ExecErrorMaxOutputExceeded", execErr.Kind)
+	}
+}
+
+func TestRunStreaming_MaxOutputBytesKillsProcessPromptly(t *testing.T) {
+	// "yes" never stops writing on its own; if exceeding maxOutputBytes
+	// didn't kill it, runStreaming would block until ctx's much longer
+	// deadline instead.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := runStreaming(ctx, "yes", nil, 100, nil)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("runStreaming() expected an error once max-output-bytes was exceeded, got none")
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("runStreaming() took %v to return, want it to abort promptly after exceeding max-output-bytes", elapsed)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shivase/gcauto/internal/config"
+)
+
+// defaultClaudePromptTemplate is used when no [prompts] override is
+// configured for the "claude" executor.
+const defaultClaudePromptTemplate = "以下のgitの差分情報に基づいて、conventional commitsフォーマットで日本語のコミットメッセージを作成してください。\n\n---\n%s\n---\n\n以下の形式で直接出力してください：\n型: 簡潔な変更内容\n\n- 具体的な変更点1\n- 具体的な変更点2\n- 具体的な変更点3\n\n注意事項：\n- 前置きや説明文は一切含めないでください\n- コミットメッセージ本文のみを出力してください\n- 🤖やCo-Authored-Byなどの情報は含めないでください\n- 型は feat/fix/docs/style/refactor/test/chore から適切なものを選択してください"
+
+// defaultGeminiPromptTemplate is used when no [prompts] override is
+// configured for the "gemini" executor.
+const defaultGeminiPromptTemplate = "以下のgitの差分情報に基づいて、conventional commitsフォーマットで日本語のコミットメッセージを作成してください。\n\n---\n%s\n---\n\n以下の形式で直接出力してください：\n型: 簡潔な変更内容\n\n- 具体的な変更点1\n- 具体的な変更点2\n- 具体的な変更点3\n\n注意事項：\n- 前置きや説明文は一切含めないでください\n- コミットメッセージ本文のみを出力してください\n- やCo-Authored-Byなどの情報は含めないでください\n- 型は feat/fix/docs/style/refactor/test/chore から適切なものを選択してください"
+
+// defaultHTTPPromptTemplate is used by the HTTP-based executors (Ollama,
+// OpenAI) when no [prompts] override is configured for them.
+const defaultHTTPPromptTemplate = "Based on the following git diff, write a commit message in the Conventional Commits format.\n\n---\n%s\n---\n\nOutput only the commit message itself, in this form:\ntype: concise summary\n\n- change 1\n- change 2\n- change 3\n\nRules:\n- No preamble or explanation\n- Output only the commit message\n- Do not include any 🤖 or Co-Authored-By information\n- Choose type from feat/fix/docs/style/refactor/test/chore"
+
+// ExecutorOptions configures how an AIExecutor streams and bounds the
+// underlying AI CLI invocation.
+type ExecutorOptions struct {
+	Timeout        time.Duration
+	MaxOutputBytes int
+	OnProgress     ProgressFunc
+}
+
+// ClaudeExecutor implements AIExecutor for the Claude model.
+type ClaudeExecutor struct {
+	Timeout        time.Duration
+	MaxOutputBytes int
+	OnProgress     ProgressFunc
+	PromptTemplate string
+}
+
+// GenerateCommitMessage generates a commit message using the Claude model.
+func (e *ClaudeExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	template := e.PromptTemplate
+	if template == "" {
+		template = defaultClaudePromptTemplate
+	}
+	return e.Complete(ctx, fmt.Sprintf(template, diff))
+}
+
+// Complete sends prompt to the Claude CLI as-is, with no commit-message
+// template wrapping.
+func (e *ClaudeExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	// #nosec G204
+	output, err := runStreaming(ctx, "claude", []string{"-p", prompt}, e.MaxOutputBytes, e.OnProgress)
+	if err != nil {
+		return "", fmt.Errorf("claude execution failed: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// GeminiExecutor implements AIExecutor for the Gemini model.
+type GeminiExecutor struct {
+	Timeout        time.Duration
+	MaxOutputBytes int
+	OnProgress     ProgressFunc
+	PromptTemplate string
+}
+
+// GenerateCommitMessage generates a commit message using the Gemini model.
+func (e *GeminiExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	template := e.PromptTemplate
+	if template == "" {
+		template = defaultGeminiPromptTemplate
+	}
+	return e.Complete(ctx, fmt.Sprintf(template, diff))
+}
+
+// Complete sends prompt to the Gemini CLI as-is, with no commit-message
+// template wrapping.
+func (e *GeminiExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	// #nosec G204
+	output, err := runStreaming(ctx, "gemini", []string{"-p", prompt}, e.MaxOutputBytes, e.OnProgress)
+	if err != nil {
+		return "", fmt.Errorf("gemini execution failed: %w", err)
+	}
+
+	lines := strings.Split(output, "\n")
+	var filteredLines []string
+	for _, line := range lines {
+		if !strings.Contains(line, "Loaded cached credentials.") {
+			filteredLines = append(filteredLines, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(filteredLines, "\n")), nil
+}
+
+// OllamaExecutor implements AIExecutor against a local Ollama server's
+// /api/generate endpoint.
+type OllamaExecutor struct {
+	BaseURL        string
+	Model          string
+	PromptTemplate string
+	Timeout        time.Duration
+}
+
+// GenerateCommitMessage generates a commit message using Ollama.
+func (e *OllamaExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	template := e.PromptTemplate
+	if template == "" {
+		template = defaultHTTPPromptTemplate
+	}
+	return e.Complete(ctx, fmt.Sprintf(template, diff))
+}
+
+// Complete sends prompt to Ollama as-is, with no commit-message template
+// wrapping.
+func (e *OllamaExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	reqBody, err := json.Marshal(map[string]any{
+		"model":  e.Model,
+		"prompt": prompt,
+		"stream": false,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build ollama request: %w", err)
+	}
+
+	url := strings.TrimSuffix(e.BaseURL, "/") + "/api/generate"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to build ollama request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("ollama request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("ollama returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Response string `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode ollama response: %w", err)
+	}
+
+	return strings.TrimSpace(result.Response), nil
+}
+
+// OpenAIExecutor implements AIExecutor against an OpenAI-compatible chat
+// completions endpoint, reading its API key from OPENAI_API_KEY.
+type OpenAIExecutor struct {
+	BaseURL        string
+	Model          string
+	APIKey         string
+	PromptTemplate string
+	Timeout        time.Duration
+}
+
+// GenerateCommitMessage generates a commit message using OpenAI.
+func (e *OpenAIExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	template := e.PromptTemplate
+	if template == "" {
+		template = defaultHTTPPromptTemplate
+	}
+	return e.Complete(ctx, fmt.Sprintf(template, diff))
+}
+
+// Complete sends prompt to OpenAI as-is, with no commit-message template
+// wrapping.
+func (e *OpenAIExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	if e.APIKey == "" {
+		return "", fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	reqBody, err := json.Marshal(map[string]any{
+		"model": e.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build openai request: %w", err)
+	}
+
+	url := strings.TrimSuffix(e.BaseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to build openai request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("openai returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode openai response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("openai returned no choices")
+	}
+
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
+
+// executorFactory builds an AIExecutor from the user's config and the
+// current invocation's ExecutorOptions.
+type executorFactory func(cfg *config.Config, opts ExecutorOptions) (AIExecutor, error)
+
+// executorRegistry holds every known executor, keyed by the name users pass
+// via -model. RegisterExecutor adds to it; built-ins register in init().
+var executorRegistry = map[string]executorFactory{}
+
+// RegisterExecutor adds a named AIExecutor factory to the registry,
+// overwriting any existing registration for that name.
+func RegisterExecutor(name string, factory executorFactory) {
+	executorRegistry[name] = factory
+}
+
+func init() {
+	RegisterExecutor("claude", func(cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
+		return &ClaudeExecutor{
+			Timeout:        opts.Timeout,
+			MaxOutputBytes: opts.MaxOutputBytes,
+			OnProgress:     opts.OnProgress,
+			PromptTemplate: cfg.Prompts["claude"],
+		}, nil
+	})
+	RegisterExecutor("gemini", func(cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
+		return &GeminiExecutor{
+			Timeout:        opts.Timeout,
+			MaxOutputBytes: opts.MaxOutputBytes,
+			OnProgress:     opts.OnProgress,
+			PromptTemplate: cfg.Prompts["gemini"],
+		}, nil
+	})
+	RegisterExecutor("ollama", func(cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
+		return &OllamaExecutor{
+			BaseURL:        cfg.Ollama.BaseURL,
+			Model:          cfg.Ollama.Model,
+			PromptTemplate: cfg.Prompts["ollama"],
+			Timeout:        opts.Timeout,
+		}, nil
+	})
+	RegisterExecutor("openai", func(cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
+		return &OpenAIExecutor{
+			BaseURL:        cfg.OpenAI.BaseURL,
+			Model:          cfg.OpenAI.Model,
+			APIKey:         os.Getenv("OPENAI_API_KEY"),
+			PromptTemplate: cfg.Prompts["openai"],
+			Timeout:        opts.Timeout,
+		}, nil
+	})
+}
+
+// newExecutor looks model up in the registry and builds it from cfg and
+// opts. An unknown model name produces an error listing every registered
+// name, so users don't have to guess what's available.
+var newExecutor = func(model string, cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
+	factory, ok := executorRegistry[model]
+	if !ok {
+		names := make([]string, 0, len(executorRegistry))
+		for name := range executorRegistry {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("invalid model specified: %s (available: %s)", model, strings.Join(names, ", "))
+	}
+	return factory(cfg, opts)
+}
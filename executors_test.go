@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shivase/gcauto/internal/config"
+)
+
+func TestOllamaExecutor_GenerateCommitMessage(t *testing.T) {
+	var gotPrompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Prompt string `json:"prompt"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		gotPrompt = body.Prompt
+		_ = json.NewEncoder(w).Encode(map[string]string{"response": "feat: add ollama backend"})
+	}))
+	defer server.Close()
+
+	executor := &OllamaExecutor{BaseURL: server.URL, Model: "llama3"}
+	message, err := executor.GenerateCommitMessage(context.Background(), "fake diff")
+	if err != nil {
+		t.Fatalf("GenerateCommitMessage() unexpected error = %v", err)
+	}
+	if message != "feat: add ollama backend" {
+		t.Errorf("GenerateCommitMessage() = %q, want %q", message, "feat: add ollama backend")
+	}
+	if !strings.Contains(gotPrompt, "fake diff") {
+		t.Errorf("GenerateCommitMessage() did not send the diff in the prompt, got %q", gotPrompt)
+	}
+}
+
+func TestOllamaExecutor_NonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	executor := &OllamaExecutor{BaseURL: server.URL, Model: "llama3"}
+	if _, err := executor.GenerateCommitMessage(context.Background(), "fake diff"); err == nil {
+		t.Error("GenerateCommitMessage() expected error on non-OK status, got none")
+	}
+}
+
+func TestOpenAIExecutor_GenerateCommitMessage(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]string{"content": "fix: handle edge case"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	executor := &OpenAIExecutor{BaseURL: server.URL, Model: "gpt-4o-mini", APIKey: "sk-test"}
+	message, err := executor.GenerateCommitMessage(context.Background(), "fake diff")
+	if err != nil {
+		t.Fatalf("GenerateCommitMessage() unexpected error = %v", err)
+	}
+	if message != "fix: handle edge case" {
+		t.Errorf("GenerateCommitMessage() = %q, want %q", message, "fix: handle edge case")
+	}
+	if gotAuth != "Bearer sk-test" {
+		t.Errorf("GenerateCommitMessage() Authorization header = %q, want %q", gotAuth, "Bearer sk-test")
+	}
+}
+
+func TestOpenAIExecutor_MissingAPIKey(t *testing.T) {
+	executor := &OpenAIExecutor{BaseURL: "http://unused.invalid", Model: "gpt-4o-mini"}
+	if _, err := executor.GenerateCommitMessage(context.Background(), "fake diff"); err == nil {
+		t.Error("GenerateCommitMessage() expected error when APIKey is empty, got none")
+	}
+}
+
+func TestNewExecutor_UnknownModelListsAvailable(t *testing.T) {
+	_, err := newExecutor("bogus", config.Defaults(), ExecutorOptions{})
+	if err == nil {
+		t.Fatal("newExecutor() expected error for unknown model, got none")
+	}
+	for _, name := range []string{"claude", "gemini", "ollama", "openai"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("newExecutor() error %q does not mention registered model %q", err, name)
+		}
+	}
+}
+
+func TestNewExecutor_KnownModels(t *testing.T) {
+	for _, name := range []string{"claude", "gemini", "ollama", "openai"} {
+		if _, err := newExecutor(name, config.Defaults(), ExecutorOptions{}); err != nil {
+			t.Errorf("newExecutor(%q) unexpected error = %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package commitmsg parses and validates commit messages against the
+// Conventional Commits 1.0.0 grammar (https://www.conventionalcommits.org/).
+package commitmsg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// headerPattern matches "<type>[(<scope>)][!]: <description>".
+var headerPattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_-]*)(\(([^)]+)\))?(!)?: (.+)$`)
+
+// footerLinePattern matches a single footer line: "<token>: <value>" or
+// "<token> #<value>". BREAKING CHANGE is the one token allowed to contain a
+// space.
+var footerLinePattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[a-zA-Z][a-zA-Z0-9-]*)(: | #)(.+)$`)
+
+// Footer is a single trailing metadata entry, e.g. "Reviewed-by: Jane" or
+// "BREAKING CHANGE: removes the v1 API".
+type Footer struct {
+	Token string
+	Value string
+}
+
+// Commit is a parsed Conventional Commits message.
+type Commit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        string
+	Footers     []Footer
+}
+
+// ParseError describes a single grammar violation found by Parse.
+type ParseError struct {
+	Field   string
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ParseErrors collects every ParseError found in a message, so callers (and
+// the auto-repair loop) can report them all at once instead of one at a time.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Parse validates message against the Conventional Commits grammar and
+// returns the structured commit on success. On failure it returns a
+// ParseErrors describing every violation found.
+func Parse(message string) (*Commit, error) {
+	var errs ParseErrors
+
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		errs = append(errs, &ParseError{Field: "header", Message: "message is empty"})
+		return nil, errs
+	}
+
+	header := lines[0]
+	match := headerPattern.FindStringSubmatch(header)
+	if match == nil {
+		errs = append(errs, &ParseError{
+			Field:   "header",
+			Message: fmt.Sprintf("does not match '<type>[(<scope>)][!]: <description>': %q", header),
+		})
+		return nil, errs
+	}
+
+	commit := &Commit{
+		Type:        match[1],
+		Scope:       match[3],
+		Breaking:    match[4] == "!",
+		Description: match[5],
+	}
+
+	if strings.TrimSpace(commit.Description) == "" {
+		errs = append(errs, &ParseError{Field: "description", Message: "must not be empty"})
+	}
+
+	if len(lines) == 1 {
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		return commit, nil
+	}
+
+	if strings.TrimSpace(lines[1]) != "" {
+		errs = append(errs, &ParseError{
+			Field:   "header",
+			Message: "must be followed by a blank line before the body or footers",
+		})
+		return nil, errs
+	}
+
+	rest := strings.Join(lines[2:], "\n")
+	paragraphs := splitParagraphs(rest)
+	if len(paragraphs) == 0 {
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		return commit, nil
+	}
+
+	bodyParagraphs := paragraphs
+	if footers, ok := parseFooters(paragraphs[len(paragraphs)-1]); ok {
+		commit.Footers = footers
+		bodyParagraphs = paragraphs[:len(paragraphs)-1]
+		for _, f := range footers {
+			if f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE" {
+				commit.Breaking = true
+			}
+		}
+	}
+
+	commit.Body = strings.Join(bodyParagraphs, "\n\n")
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return commit, nil
+}
+
+// splitParagraphs splits s into blocks separated by one or more blank lines.
+func splitParagraphs(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	raw := strings.Split(s, "\n\n")
+	paragraphs := make([]string, 0, len(raw))
+	for _, p := range raw {
+		p = strings.Trim(p, "\n")
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		paragraphs = append(paragraphs, p)
+	}
+	return paragraphs
+}
+
+// parseFooters reports whether every line of paragraph is a valid footer
+// line, returning the parsed footers if so.
+func parseFooters(paragraph string) ([]Footer, bool) {
+	lines := strings.Split(paragraph, "\n")
+	footers := make([]Footer, 0, len(lines))
+	for _, line := range lines {
+		match := footerLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, false
+		}
+		footers = append(footers, Footer{Token: match[1], Value: match[3]})
+	}
+	return footers, true
+}
@@ -0,0 +1,98 @@
+package commitmsg
+
+import (
+	"testing"
+)
+
+func TestParse_ValidHeaderOnly(t *testing.T) {
+	commit, err := Parse("feat: add login flow")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	if commit.Type != "feat" {
+		t.Errorf("Type = %q, want %q", commit.Type, "feat")
+	}
+	if commit.Scope != "" {
+		t.Errorf("Scope = %q, want empty", commit.Scope)
+	}
+	if commit.Breaking {
+		t.Error("Breaking = true, want false")
+	}
+	if commit.Description != "add login flow" {
+		t.Errorf("Description = %q, want %q", commit.Description, "add login flow")
+	}
+}
+
+func TestParse_WithScopeAndBreakingBang(t *testing.T) {
+	commit, err := Parse("feat(auth)!: drop legacy tokens")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	if commit.Scope != "auth" {
+		t.Errorf("Scope = %q, want %q", commit.Scope, "auth")
+	}
+	if !commit.Breaking {
+		t.Error("Breaking = false, want true")
+	}
+}
+
+func TestParse_WithBodyAndFooters(t *testing.T) {
+	message := "fix: correct off-by-one in pagination\n\n" +
+		"The previous implementation dropped the last page of results.\n\n" +
+		"Reviewed-by: Jane Doe\n" +
+		"Refs: #123"
+
+	commit, err := Parse(message)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	if commit.Body != "The previous implementation dropped the last page of results." {
+		t.Errorf("Body = %q", commit.Body)
+	}
+	if len(commit.Footers) != 2 {
+		t.Fatalf("len(Footers) = %d, want 2", len(commit.Footers))
+	}
+	if commit.Footers[0].Token != "Reviewed-by" || commit.Footers[0].Value != "Jane Doe" {
+		t.Errorf("Footers[0] = %+v", commit.Footers[0])
+	}
+	if commit.Footers[1].Token != "Refs" || commit.Footers[1].Value != "#123" {
+		t.Errorf("Footers[1] = %+v", commit.Footers[1])
+	}
+}
+
+func TestParse_BreakingChangeFooterSetsBreaking(t *testing.T) {
+	message := "refactor: simplify config loader\n\n" +
+		"BREAKING CHANGE: config.toml keys are now lowercase only"
+
+	commit, err := Parse(message)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error = %v", err)
+	}
+	if !commit.Breaking {
+		t.Error("Breaking = false, want true from BREAKING CHANGE footer")
+	}
+	if len(commit.Footers) != 1 {
+		t.Fatalf("len(Footers) = %d, want 1", len(commit.Footers))
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{name: "empty message", message: ""},
+		{name: "missing colon", message: "feat add login flow"},
+		{name: "missing description", message: "feat: "},
+		{name: "missing blank line before body", message: "feat: add thing\nnot blank"},
+		{name: "unknown type is still required to look like an identifier", message: "123: bad type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.message); err == nil {
+				t.Errorf("Parse(%q) expected error, got none", tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package config loads gcauto's user configuration from a TOML file at
+// ~/.config/gcauto/config.toml, so local-model endpoints and prompt template
+// overrides don't have to live on the command line.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OllamaConfig configures the Ollama HTTP backend.
+type OllamaConfig struct {
+	BaseURL string
+	Model   string
+}
+
+// OpenAIConfig configures the OpenAI HTTP backend.
+type OpenAIConfig struct {
+	BaseURL string
+	Model   string
+}
+
+// Config holds gcauto's user configuration.
+type Config struct {
+	Ollama OllamaConfig
+	OpenAI OpenAIConfig
+	// Prompts holds per-executor prompt template overrides, keyed by
+	// executor name (e.g. "claude", "ollama"). A template must contain
+	// exactly one %s placeholder for the diff.
+	Prompts map[string]string
+}
+
+// Defaults returns the configuration used when no config file is present.
+func Defaults() *Config {
+	return &Config{
+		Ollama:  OllamaConfig{BaseURL: "http://localhost:11434", Model: "llama3"},
+		OpenAI:  OpenAIConfig{BaseURL: "https://api.openai.com/v1", Model: "gpt-4o-mini"},
+		Prompts: map[string]string{},
+	}
+}
+
+// DefaultPath returns ~/.config/gcauto/config.toml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "gcauto", "config.toml"), nil
+}
+
+// Load reads config from path. A missing file is not an error: it yields
+// Defaults(). Only the flat subset of TOML gcauto needs is supported:
+// [section] headers and "key = value" pairs, with optional double-quotes
+// around string values.
+func Load(path string) (*Config, error) {
+	cfg := Defaults()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch section {
+		case "ollama":
+			switch key {
+			case "base_url":
+				cfg.Ollama.BaseURL = value
+			case "model":
+				cfg.Ollama.Model = value
+			}
+		case "openai":
+			switch key {
+			case "base_url":
+				cfg.OpenAI.BaseURL = value
+			case "model":
+				cfg.OpenAI.Model = value
+			}
+		case "prompts":
+			cfg.Prompts[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for name, template := range cfg.Prompts {
+		if n := strings.Count(template, "%s"); n != 1 {
+			return nil, fmt.Errorf("invalid [prompts] template for %q: must contain exactly one %%s placeholder, found %d", name, n)
+		}
+	}
+
+	return cfg, nil
+}
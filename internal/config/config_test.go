@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad_MissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "does-not-exist.toml"))
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	want := Defaults()
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want defaults %+v", cfg, want)
+	}
+}
+
+func TestLoad_ParsesSections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `# gcauto config
+[ollama]
+base_url = "http://example.internal:11434"
+model = "codellama"
+
+[openai]
+base_url = "https://my-proxy.example.com/v1"
+model = "gpt-4o"
+
+[prompts]
+ollama = "Summarize this diff: %s"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	if cfg.Ollama.BaseURL != "http://example.internal:11434" {
+		t.Errorf("Ollama.BaseURL = %q, want %q", cfg.Ollama.BaseURL, "http://example.internal:11434")
+	}
+	if cfg.Ollama.Model != "codellama" {
+		t.Errorf("Ollama.Model = %q, want %q", cfg.Ollama.Model, "codellama")
+	}
+	if cfg.OpenAI.BaseURL != "https://my-proxy.example.com/v1" {
+		t.Errorf("OpenAI.BaseURL = %q, want %q", cfg.OpenAI.BaseURL, "https://my-proxy.example.com/v1")
+	}
+	if cfg.OpenAI.Model != "gpt-4o" {
+		t.Errorf("OpenAI.Model = %q, want %q", cfg.OpenAI.Model, "gpt-4o")
+	}
+	if cfg.Prompts["ollama"] != "Summarize this diff: %s" {
+		t.Errorf("Prompts[ollama] = %q, want %q", cfg.Prompts["ollama"], "Summarize this diff: %s")
+	}
+}
+
+func TestLoad_RejectsPromptTemplateWithoutPlaceholder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[prompts]\nollama = \"Summarize this diff\"\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() expected error for a prompt template with no percent-s placeholder, got none")
+	}
+}
+
+func TestLoad_RejectsPromptTemplateWithExtraPlaceholders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[prompts]\nollama = \"Summarize %s and %s\"\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() expected error for a prompt template with more than one percent-s placeholder, got none")
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() unexpected error = %v", err)
+	}
+	if filepath.Base(path) != "config.toml" {
+		t.Errorf("DefaultPath() = %q, want it to end in config.toml", path)
+	}
+}
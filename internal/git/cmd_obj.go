@@ -0,0 +1,49 @@
+// Package git provides a thin, testable wrapper around git CLI invocations,
+// modeled on lazygit's oscommands/cmd_obj pattern: every git invocation is
+// built as a CmdObj and executed through an injectable Runner, so tests never
+// need to swap package-level variables to fake out exec.Command.
+package git
+
+import "os/exec"
+
+// CmdObjBuilder constructs CmdObj values, wiring in a shared Runner so
+// callers never touch exec.Cmd directly.
+type CmdObjBuilder struct {
+	runner Runner
+}
+
+// NewCmdObjBuilder returns a builder that executes commands through runner.
+func NewCmdObjBuilder(runner Runner) *CmdObjBuilder {
+	return &CmdObjBuilder{runner: runner}
+}
+
+// New builds a CmdObj for name with args, ready to Run.
+func (b *CmdObjBuilder) New(name string, args ...string) *CmdObj {
+	return &CmdObj{name: name, args: args, runner: b.runner}
+}
+
+// CmdObj represents a single command invocation, ready to run via its Runner.
+type CmdObj struct {
+	name   string
+	args   []string
+	runner Runner
+}
+
+func (c *CmdObj) cmd() *exec.Cmd {
+	return exec.Command(c.name, c.args...)
+}
+
+// Run executes the command, discarding any output.
+func (c *CmdObj) Run() error {
+	return c.runner.Run(c.cmd())
+}
+
+// RunWithOutput executes the command and returns its stdout.
+func (c *CmdObj) RunWithOutput() (string, error) {
+	return c.runner.RunWithOutput(c.cmd())
+}
+
+// RunWithOutputs executes the command and returns stdout and stderr separately.
+func (c *CmdObj) RunWithOutputs() (stdout string, stderr string, err error) {
+	return c.runner.RunWithOutputs(c.cmd())
+}
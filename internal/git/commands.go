@@ -0,0 +1,102 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommitOpts configures a Commit invocation.
+type CommitOpts struct {
+	Amend   bool
+	Signoff bool
+	GPGSign bool
+}
+
+// GitCommand exposes the git operations gcauto needs, each built as a CmdObj
+// so callers never shell out directly.
+type GitCommand struct {
+	cmd *CmdObjBuilder
+}
+
+// NewGitCommand returns a GitCommand that runs against the real OS.
+func NewGitCommand() *GitCommand {
+	return NewGitCommandWithRunner(OSRunner{})
+}
+
+// NewGitCommandWithRunner returns a GitCommand driven by runner, for tests.
+func NewGitCommandWithRunner(runner Runner) *GitCommand {
+	return &GitCommand{cmd: NewCmdObjBuilder(runner)}
+}
+
+// IsRepo reports whether the current directory is inside a git work tree.
+func (gc *GitCommand) IsRepo() bool {
+	out, err := gc.cmd.New("git", "rev-parse", "--is-inside-work-tree").RunWithOutput()
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// StagedDiff returns the diff of currently staged changes.
+func (gc *GitCommand) StagedDiff() (string, error) {
+	out, err := gc.cmd.New("git", "diff", "--staged").RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get staged diff: %w", err)
+	}
+	return out, nil
+}
+
+// HasStagedChanges reports whether there is anything staged for commit.
+func (gc *GitCommand) HasStagedChanges() (bool, error) {
+	diff, err := gc.StagedDiff()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(diff) != "", nil
+}
+
+// RootDir returns the absolute path to the top level of the working tree.
+func (gc *GitCommand) RootDir() (string, error) {
+	out, err := gc.cmd.New("git", "rev-parse", "--show-toplevel").RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CurrentBranch returns the name of the currently checked out branch.
+func (gc *GitCommand) CurrentBranch() (string, error) {
+	out, err := gc.cmd.New("git", "rev-parse", "--abbrev-ref", "HEAD").RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// RecentCommits returns the subjects of the n most recent commits, newest first.
+func (gc *GitCommand) RecentCommits(n int) ([]string, error) {
+	out, err := gc.cmd.New("git", "log", fmt.Sprintf("-%d", n), "--pretty=format:%s").RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent commits: %w", err)
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Commit creates a commit with message, applying opts.
+func (gc *GitCommand) Commit(message string, opts CommitOpts) error {
+	args := []string{"commit", "-m", message}
+	if opts.Amend {
+		args = append(args, "--amend")
+	}
+	if opts.Signoff {
+		args = append(args, "--signoff")
+	}
+	if opts.GPGSign {
+		args = append(args, "-S")
+	}
+	if err := gc.cmd.New("git", args...).Run(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// fakeRunner is a Runner that returns canned output instead of touching the
+// real OS, and records the arguments of the last command it was asked to run.
+type fakeRunner struct {
+	output    string
+	stderr    string
+	err       error
+	gotArgs   []string
+	runCalled bool
+}
+
+func (f *fakeRunner) Run(cmd *exec.Cmd) error {
+	f.runCalled = true
+	f.gotArgs = cmd.Args
+	return f.err
+}
+
+func (f *fakeRunner) RunWithOutput(cmd *exec.Cmd) (string, error) {
+	f.gotArgs = cmd.Args
+	return f.output, f.err
+}
+
+func (f *fakeRunner) RunWithOutputs(cmd *exec.Cmd) (string, string, error) {
+	f.gotArgs = cmd.Args
+	return f.output, f.stderr, f.err
+}
+
+func TestStagedDiff(t *testing.T) {
+	runner := &fakeRunner{output: "diff --git a/foo b/foo\n"}
+	gc := NewGitCommandWithRunner(runner)
+
+	diff, err := gc.StagedDiff()
+	if err != nil {
+		t.Fatalf("StagedDiff() unexpected error = %v", err)
+	}
+	if diff != runner.output {
+		t.Errorf("StagedDiff() = %q, want %q", diff, runner.output)
+	}
+	if !strings.Contains(strings.Join(runner.gotArgs, " "), "diff --staged") {
+		t.Errorf("StagedDiff() built args %v, want them to contain 'diff --staged'", runner.gotArgs)
+	}
+}
+
+func TestHasStagedChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{name: "empty diff", output: "", want: false},
+		{name: "whitespace only diff", output: "\n\n", want: false},
+		{name: "non-empty diff", output: "diff --git a/foo b/foo\n", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := NewGitCommandWithRunner(&fakeRunner{output: tt.output})
+
+			got, err := gc.HasStagedChanges()
+			if err != nil {
+				t.Fatalf("HasStagedChanges() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasStagedChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootDir(t *testing.T) {
+	runner := &fakeRunner{output: "/home/user/project\n"}
+	gc := NewGitCommandWithRunner(runner)
+
+	root, err := gc.RootDir()
+	if err != nil {
+		t.Fatalf("RootDir() unexpected error = %v", err)
+	}
+	if root != "/home/user/project" {
+		t.Errorf("RootDir() = %q, want %q", root, "/home/user/project")
+	}
+}
+
+func TestCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     CommitOpts
+		wantArgs []string
+	}{
+		{
+			name:     "plain commit",
+			opts:     CommitOpts{},
+			wantArgs: []string{"commit", "-m", "feat: add thing"},
+		},
+		{
+			name:     "amend",
+			opts:     CommitOpts{Amend: true},
+			wantArgs: []string{"commit", "-m", "feat: add thing", "--amend"},
+		},
+		{
+			name:     "signoff and gpg sign",
+			opts:     CommitOpts{Signoff: true, GPGSign: true},
+			wantArgs: []string{"commit", "-m", "feat: add thing", "--signoff", "-S"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{}
+			gc := NewGitCommandWithRunner(runner)
+
+			if err := gc.Commit("feat: add thing", tt.opts); err != nil {
+				t.Fatalf("Commit() unexpected error = %v", err)
+			}
+			if !runner.runCalled {
+				t.Fatal("Commit() did not run the git command")
+			}
+			gotArgs := runner.gotArgs[1:] // drop argv[0] ("git")
+			if len(gotArgs) != len(tt.wantArgs) {
+				t.Fatalf("Commit() built args %v, want %v", gotArgs, tt.wantArgs)
+			}
+			for i, want := range tt.wantArgs {
+				if gotArgs[i] != want {
+					t.Errorf("Commit() arg[%d] = %q, want %q", i, gotArgs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRecentCommits(t *testing.T) {
+	runner := &fakeRunner{output: "feat: add thing\nfix: squash bug\n"}
+	gc := NewGitCommandWithRunner(runner)
+
+	commits, err := gc.RecentCommits(2)
+	if err != nil {
+		t.Fatalf("RecentCommits() unexpected error = %v", err)
+	}
+	want := []string{"feat: add thing", "fix: squash bug"}
+	if len(commits) != len(want) {
+		t.Fatalf("RecentCommits() = %v, want %v", commits, want)
+	}
+	for i, w := range want {
+		if commits[i] != w {
+			t.Errorf("RecentCommits()[%d] = %q, want %q", i, commits[i], w)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Runner executes *exec.Cmd values built by a CmdObj. The default OSRunner
+// shells out for real; tests supply a fake implementation instead so no
+// package-level variable swapping is needed.
+type Runner interface {
+	Run(cmd *exec.Cmd) error
+	RunWithOutput(cmd *exec.Cmd) (string, error)
+	RunWithOutputs(cmd *exec.Cmd) (stdout string, stderr string, err error)
+}
+
+// OSRunner is the default Runner, executing commands against the real OS.
+type OSRunner struct{}
+
+// Run executes cmd, streaming its stdout/stderr to the current process so
+// interactive git output (e.g. hook messages) is still visible.
+func (OSRunner) Run(cmd *exec.Cmd) error {
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// RunWithOutput executes cmd and returns its stdout.
+func (OSRunner) RunWithOutput(cmd *exec.Cmd) (string, error) {
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return string(output), fmt.Errorf("%w: %s", err, string(exitErr.Stderr))
+		}
+		return string(output), err
+	}
+	return string(output), nil
+}
+
+// RunWithOutputs executes cmd and returns its stdout and stderr separately.
+func (OSRunner) RunWithOutputs(cmd *exec.Cmd) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -0,0 +1,50 @@
+// Package hook installs and runs the gcauto prepare-commit-msg git hook, so
+// users get an AI-suggested commit message during their normal `git commit`
+// flow instead of only via the interactive prompt in main.
+package hook
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// marker identifies a hook script installed by gcauto, so Install can detect
+// an existing installation and remain idempotent.
+const marker = "# gcauto:prepare-commit-msg"
+
+const scriptTemplate = `#!/bin/sh
+%s
+# Installed by ` + "`gcauto hook install`" + `. Safe to remove this file to disable.
+exec gcauto hook run prepare-commit-msg "$@"
+`
+
+// Install writes a prepare-commit-msg hook into repoRoot's .git/hooks
+// directory. It is idempotent: re-running it on an already-installed hook is
+// a no-op, and it refuses to clobber a hook it didn't install.
+func Install(repoRoot string) error {
+	hooksDir := filepath.Join(repoRoot, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	path := filepath.Join(hooksDir, "prepare-commit-msg")
+
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		if strings.Contains(string(existing), marker) {
+			return nil
+		}
+		return fmt.Errorf("%s already exists and was not installed by gcauto; remove it or merge manually", path)
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to inspect existing hook: %w", err)
+	}
+
+	script := fmt.Sprintf(scriptTemplate, marker)
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package hook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstall_CreatesHook(t *testing.T) {
+	repoRoot := t.TempDir()
+
+	if err := Install(repoRoot); err != nil {
+		t.Fatalf("Install() unexpected error = %v", err)
+	}
+
+	path := filepath.Join(repoRoot, ".git", "hooks", "prepare-commit-msg")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Install() did not create %s: %v", path, err)
+	}
+	if info.Mode()&0o111 == 0 {
+		t.Errorf("Install() created a non-executable hook: mode = %v", info.Mode())
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read installed hook: %v", err)
+	}
+	if !strings.Contains(string(content), marker) {
+		t.Errorf("installed hook missing marker %q:\n%s", marker, content)
+	}
+	if !strings.Contains(string(content), "gcauto hook run prepare-commit-msg") {
+		t.Errorf("installed hook does not invoke gcauto:\n%s", content)
+	}
+}
+
+func TestInstall_Idempotent(t *testing.T) {
+	repoRoot := t.TempDir()
+
+	if err := Install(repoRoot); err != nil {
+		t.Fatalf("first Install() unexpected error = %v", err)
+	}
+	path := filepath.Join(repoRoot, ".git", "hooks", "prepare-commit-msg")
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read installed hook: %v", err)
+	}
+
+	if err := Install(repoRoot); err != nil {
+		t.Fatalf("second Install() unexpected error = %v", err)
+	}
+	second, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read installed hook: %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Errorf("Install() was not idempotent: first = %q, second = %q", first, second)
+	}
+}
+
+func TestInstall_RefusesForeignHook(t *testing.T) {
+	repoRoot := t.TempDir()
+	hooksDir := filepath.Join(repoRoot, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(hooksDir, "prepare-commit-msg")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho custom hook\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Install(repoRoot); err == nil {
+		t.Error("Install() expected error for a pre-existing foreign hook, got none")
+	}
+}
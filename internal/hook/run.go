@@ -0,0 +1,68 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DiffSource provides the staged diff to summarize.
+type DiffSource interface {
+	StagedDiff() (string, error)
+}
+
+// Generator produces a commit message from a diff.
+type Generator interface {
+	GenerateCommitMessage(ctx context.Context, diff string) (string, error)
+}
+
+// GeneratorFunc adapts a plain function to Generator, the way
+// http.HandlerFunc adapts a function to http.Handler. It lets a caller wire
+// Run to arbitrarily sophisticated generation logic (diff chunking, message
+// repair) via a closure, without this package needing to import whatever
+// packages that logic lives in.
+type GeneratorFunc func(ctx context.Context, diff string) (string, error)
+
+// GenerateCommitMessage calls f.
+func (f GeneratorFunc) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	return f(ctx, diff)
+}
+
+// Run implements the prepare-commit-msg entrypoint. When source is empty (a
+// plain `git commit` with no -m, template, merge, or squash in play) and
+// there is a staged diff, it generates a message via generator and writes it
+// into msgFile ahead of git's own commented instructions. Any other source
+// means the user already has a message, so Run leaves the file untouched.
+func Run(ctx context.Context, diffSource DiffSource, generator Generator, msgFile string, source string) error {
+	if source != "" {
+		return nil
+	}
+
+	diff, err := diffSource.StagedDiff()
+	if err != nil {
+		return fmt.Errorf("failed to get staged diff: %w", err)
+	}
+	if strings.TrimSpace(diff) == "" {
+		return nil
+	}
+
+	message, err := generator.GenerateCommitMessage(ctx, diff)
+	if err != nil {
+		return fmt.Errorf("failed to generate commit message: %w", err)
+	}
+	if strings.TrimSpace(message) == "" {
+		return nil
+	}
+
+	existing, err := os.ReadFile(msgFile)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message file: %w", err)
+	}
+
+	content := message + "\n\n" + string(existing)
+	if err := os.WriteFile(msgFile, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write commit message file: %w", err)
+	}
+	return nil
+}
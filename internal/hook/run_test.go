@@ -0,0 +1,96 @@
+package hook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeDiffSource struct {
+	diff string
+	err  error
+}
+
+func (f *fakeDiffSource) StagedDiff() (string, error) {
+	return f.diff, f.err
+}
+
+type fakeGenerator struct {
+	message string
+	err     error
+	calls   int
+}
+
+func (f *fakeGenerator) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	f.calls++
+	return f.message, f.err
+}
+
+func writeMsgFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun_SkipsWhenSourceSet(t *testing.T) {
+	msgFile := writeMsgFile(t, "# please enter a commit message\n")
+	generator := &fakeGenerator{message: "feat: should not be used"}
+
+	err := Run(context.Background(), &fakeDiffSource{diff: "diff --git a/x b/x"}, generator, msgFile, "message")
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if generator.calls != 0 {
+		t.Errorf("Run() called the generator when source was set")
+	}
+}
+
+func TestRun_SkipsWhenNoDiff(t *testing.T) {
+	msgFile := writeMsgFile(t, "# please enter a commit message\n")
+	generator := &fakeGenerator{message: "feat: should not be used"}
+
+	err := Run(context.Background(), &fakeDiffSource{diff: ""}, generator, msgFile, "")
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if generator.calls != 0 {
+		t.Errorf("Run() called the generator with no staged diff")
+	}
+}
+
+func TestRun_WritesMessage(t *testing.T) {
+	original := "# please enter a commit message\n"
+	msgFile := writeMsgFile(t, original)
+	generator := &fakeGenerator{message: "feat: add thing"}
+
+	err := Run(context.Background(), &fakeDiffSource{diff: "diff --git a/x b/x"}, generator, msgFile, "")
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	content, err := os.ReadFile(msgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "feat: add thing") {
+		t.Errorf("Run() did not write the generated message, got %q", content)
+	}
+	if !strings.Contains(string(content), original) {
+		t.Errorf("Run() dropped git's original template, got %q", content)
+	}
+}
+
+func TestRun_GeneratorError(t *testing.T) {
+	msgFile := writeMsgFile(t, "# please enter a commit message\n")
+	generator := &fakeGenerator{err: os.ErrInvalid}
+
+	err := Run(context.Background(), &fakeDiffSource{diff: "diff --git a/x b/x"}, generator, msgFile, "")
+	if err == nil {
+		t.Error("Run() expected error from generator, got none")
+	}
+}
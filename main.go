@@ -3,89 +3,64 @@ package main
 
 import (
 	"bufio"
-	"errors"
+	"context"
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
+	"os/signal"
+	"strconv"
 	"strings"
-)
+	"time"
 
-var execCommand = exec.Command
+	"github.com/shivase/gcauto/internal/config"
+	"github.com/shivase/gcauto/internal/git"
+	"github.com/shivase/gcauto/internal/hook"
+)
 
 // AIExecutor defines the interface for generating commit messages.
+// GenerateCommitMessage wraps diff in the executor's commit-message prompt
+// template. Complete sends prompt to the model as-is, for callers (the
+// chunked map and reduce passes, the commit-message repair loop) that already have a
+// complete, purpose-built prompt and must not have it wrapped again.
 type AIExecutor interface {
-	GenerateCommitMessage(diff string) (string, error)
+	GenerateCommitMessage(ctx context.Context, diff string) (string, error)
+	Complete(ctx context.Context, prompt string) (string, error)
 }
 
-// ClaudeExecutor implements AIExecutor for the Claude model.
-type ClaudeExecutor struct{}
-
-// GenerateCommitMessage generates a commit message using the Claude model.
-func (e *ClaudeExecutor) GenerateCommitMessage(diff string) (string, error) {
-	prompt := fmt.Sprintf("以下のgitの差分情報に基づいて、conventional commitsフォーマットで日本語のコミットメッセージを作成してください。\n\n---\n%s\n---\n\n以下の形式で直接出力してください：\n型: 簡潔な変更内容\n\n- 具体的な変更点1\n- 具体的な変更点2\n- 具体的な変更点3\n\n注意事項：\n- 前置きや説明文は一切含めないでください\n- コミットメッセージ本文のみを出力してください\n- 🤖やCo-Authored-Byなどの情報は含めないでください\n- 型は feat/fix/docs/style/refactor/test/chore から適切なものを選択してください", diff)
-
-	// #nosec G204
-	cmd := execCommand("claude", "-p", prompt)
-	output, err := cmd.Output()
-	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return "", fmt.Errorf("claude execution failed: %w: %s", err, string(exitErr.Stderr))
-		}
-		return "", fmt.Errorf("failed to run claude command: %w", err)
-	}
-	return strings.TrimSpace(string(output)), nil
+// gitClient is the subset of *git.GitCommand that main needs. It's an
+// interface so tests can supply a fake; main obtains it through the
+// newGitClient package variable, which tests replace to inject that fake.
+type gitClient interface {
+	StagedDiff() (string, error)
+	Commit(message string, opts git.CommitOpts) error
 }
 
-// GeminiExecutor implements AIExecutor for the Gemini model.
-type GeminiExecutor struct{}
-
-// GenerateCommitMessage generates a commit message using the Gemini model.
-func (e *GeminiExecutor) GenerateCommitMessage(diff string) (string, error) {
-	prompt := fmt.Sprintf("以下のgitの差分情報に基づいて、conventional commitsフォーマットで日本語のコミットメッセージを作成してください。\n\n---\n%s\n---\n\n以下の形式で直接出力してください：\n型: 簡潔な変更内容\n\n- 具体的な変更点1\n- 具体的な変更点2\n- 具体的な変更点3\n\n注意事項：\n- 前置きや説明文は一切含めないでください\n- コミットメッセージ本文のみを出力してください\n- やCo-Authored-Byなどの情報は含めないでください\n- 型は feat/fix/docs/style/refactor/test/chore から適切なものを選択してください", diff)
+var newGitClient = func() gitClient { return git.NewGitCommand() }
 
-	// #nosec G204
-	cmd := execCommand("gemini", "-p", prompt)
-	output, err := cmd.Output()
-	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return "", fmt.Errorf("gemini execution failed: %w: %s", err, string(exitErr.Stderr))
-		}
-		return "", fmt.Errorf("failed to run gemini command: %w", err)
-	}
+var version = "dev" // Can be set during build
 
-	lines := strings.Split(string(output), "\n")
-	var filteredLines []string
-	for _, line := range lines {
-		if !strings.Contains(line, "Loaded cached credentials.") {
-			filteredLines = append(filteredLines, line)
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "hook" {
+		if err := runHookCommand(os.Args[2:]); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(1)
 		}
+		os.Exit(0)
 	}
 
-	return strings.TrimSpace(strings.Join(filteredLines, "\n")), nil
-}
-
-var newExecutor = func(model string) (AIExecutor, error) {
-	switch model {
-	case "claude":
-		return &ClaudeExecutor{}, nil
-	case "gemini":
-		return &GeminiExecutor{}, nil
-	default:
-		return nil, fmt.Errorf("invalid model specified: %s", model)
-	}
-}
-
-var version = "dev" // Can be set during build
+	defaultConfigPath, _ := config.DefaultPath()
 
-func main() {
-	model := flag.String("model", "claude", "AI model to use (claude or gemini)")
-	modelShort := flag.String("m", "", "AI model to use (claude or gemini) (shorthand for -model)")
+	model := flag.String("model", "claude", "AI model to use (claude, gemini, ollama, or openai)")
+	modelShort := flag.String("m", "", "AI model to use (claude, gemini, ollama, or openai) (shorthand for -model)")
+	configPath := flag.String("config", defaultConfigPath, "path to gcauto's config.toml")
 	showHelp := flag.Bool("h", false, "Show help message")
 	showHelpLong := flag.Bool("help", false, "Show help message (longhand for -h)")
 	showVersion := flag.Bool("version", false, "Show version information")
+	maxDiffBytes := flag.Int("max-diff-bytes", 8000, "split diffs larger than this many bytes before summarizing")
+	chunkStrategy := flag.String("chunk-strategy", "file", "how to split large diffs before summarizing: none, file, or hunk")
+	timeout := flag.Duration("timeout", 2*time.Minute, "max time to wait for the AI CLI to respond")
+	maxOutputBytes := flag.Int("max-output-bytes", 2*1024*1024, "abort if the AI CLI produces more than this many bytes of output")
+	maxRetries := flag.Int("max-retries", 2, "how many times to ask the AI to fix a commit message that fails Conventional Commits validation")
 
 	flag.Usage = func() {
 		_, _ = fmt.Fprintf(os.Stderr, "gcauto: AI-powered git commit message generator.\n\n")
@@ -111,15 +86,44 @@ func main() {
 		os.Exit(0)
 	}
 
+	strategy := ChunkStrategy(*chunkStrategy)
+	switch strategy {
+	case ChunkStrategyNone, ChunkStrategyFile, ChunkStrategyHunk:
+	default:
+		fmt.Printf("❌ Error: invalid -chunk-strategy %q (want none, file, or hunk)\n", *chunkStrategy)
+		os.Exit(1)
+	}
+
+	if *maxRetries < 0 {
+		fmt.Printf("❌ Error: invalid -max-retries %d (must be >= 0)\n", *maxRetries)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Printf("❌ Error: Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Cancel the in-flight AI CLI call on Ctrl-C instead of leaving it to hang.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	fmt.Printf("🚀 gcauto: Starting automatic commit process using %s...\n", *model)
 
-	executor, err := newExecutor(*model)
+	executor, err := newExecutor(*model, cfg, ExecutorOptions{
+		Timeout:        *timeout,
+		MaxOutputBytes: *maxOutputBytes,
+		OnProgress:     func(line string) { fmt.Println(line) },
+	})
 	if err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	diff, err := getStagedDiff()
+	gitCmd := newGitClient()
+
+	diff, err := gitCmd.StagedDiff()
 	if err != nil {
 		fmt.Printf("❌ Error: Failed to get git diff: %v\n", err)
 		os.Exit(1)
@@ -130,7 +134,7 @@ func main() {
 		os.Exit(0)
 	}
 
-	commitMessage, err := executor.GenerateCommitMessage(diff)
+	commitMessage, err := GenerateCommitMessageChunked(ctx, executor, diff, *maxDiffBytes, strategy)
 	if err != nil {
 		fmt.Printf("❌ Error: Failed to generate commit message: %v\n", err)
 		os.Exit(1)
@@ -141,6 +145,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	commitMessage, err = repairCommitMessage(ctx, executor, commitMessage, *maxRetries)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	fmt.Println("\n📝 Generated Commit Message:")
 	fmt.Println("===================================")
 	fmt.Println(commitMessage)
@@ -157,7 +167,7 @@ func main() {
 
 	response = strings.TrimSpace(strings.ToLower(response))
 	if response == "y" || response == "yes" {
-		if err := gitCommit(commitMessage); err != nil {
+		if err := gitCmd.Commit(commitMessage, git.CommitOpts{}); err != nil {
 			fmt.Printf("\n❌ Commit failed: %v\n", err)
 			os.Exit(1)
 		}
@@ -168,20 +178,110 @@ func main() {
 	}
 }
 
-func gitCommit(message string) error {
-	cmd := execCommand("git", "commit", "-m", message)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// runHookCommand dispatches `gcauto hook <install|run> ...`.
+func runHookCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: gcauto hook <install|run> ...")
+	}
+
+	switch args[0] {
+	case "install":
+		return installHook()
+	case "run":
+		return runHookEntrypoint(args[1:])
+	default:
+		return fmt.Errorf("unknown hook subcommand: %s", args[0])
+	}
 }
 
-func _getStagedDiff() (string, error) {
-	cmd := execCommand("git", "diff", "--staged")
-	output, err := cmd.Output()
+// installHook writes a prepare-commit-msg hook into the current repository.
+func installHook() error {
+	gc := git.NewGitCommand()
+	root, err := gc.RootDir()
 	if err != nil {
-		return "", err
+		return fmt.Errorf("not a git repository: %w", err)
 	}
-	return string(output), nil
+
+	if err := hook.Install(root); err != nil {
+		return err
+	}
+	fmt.Println("✅ Installed prepare-commit-msg hook.")
+	return nil
 }
 
-var getStagedDiff = _getStagedDiff
+// runHookEntrypoint handles `gcauto hook run prepare-commit-msg <file> [source] [sha]`,
+// as invoked by the hook script written by installHook.
+func runHookEntrypoint(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: gcauto hook run prepare-commit-msg <file> [source] [sha]")
+	}
+	if args[0] != "prepare-commit-msg" {
+		return fmt.Errorf("unsupported hook: %s", args[0])
+	}
+
+	msgFile := args[1]
+	var source string
+	if len(args) > 2 {
+		source = args[2]
+	}
+
+	model := os.Getenv("GCAUTO_MODEL")
+	if model == "" {
+		model = "claude"
+	}
+
+	configPath := os.Getenv("GCAUTO_CONFIG")
+	if configPath == "" {
+		configPath, _ = config.DefaultPath()
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	maxDiffBytes := 8000
+	if v := os.Getenv("GCAUTO_MAX_DIFF_BYTES"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GCAUTO_MAX_DIFF_BYTES %q: %w", v, err)
+		}
+		maxDiffBytes = parsed
+	}
+
+	strategy := ChunkStrategy(os.Getenv("GCAUTO_CHUNK_STRATEGY"))
+	if strategy == "" {
+		strategy = ChunkStrategyFile
+	}
+	switch strategy {
+	case ChunkStrategyNone, ChunkStrategyFile, ChunkStrategyHunk:
+	default:
+		return fmt.Errorf("invalid GCAUTO_CHUNK_STRATEGY %q (want none, file, or hunk)", strategy)
+	}
+
+	maxRetries := 2
+	if v := os.Getenv("GCAUTO_MAX_RETRIES"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GCAUTO_MAX_RETRIES %q: %w", v, err)
+		}
+		maxRetries = parsed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	executor, err := newExecutor(model, cfg, ExecutorOptions{Timeout: 2 * time.Minute})
+	if err != nil {
+		return err
+	}
+
+	generate := hook.GeneratorFunc(func(ctx context.Context, diff string) (string, error) {
+		message, err := GenerateCommitMessageChunked(ctx, executor, diff, maxDiffBytes, strategy)
+		if err != nil {
+			return "", err
+		}
+		return repairCommitMessage(ctx, executor, message, maxRetries)
+	})
+
+	return hook.Run(ctx, newGitClient(), generate, msgFile, source)
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/shivase/gcauto/internal/config"
+	"github.com/shivase/gcauto/internal/git"
 )
 
 // MockAIExecutor is a mock implementation of AIExecutor for testing.
@@ -16,8 +20,16 @@ type MockAIExecutor struct {
 	MockError    error
 }
 
-// Execute returns the mock response or error.
-func (m *MockAIExecutor) Execute(prompt string) (string, error) {
+// GenerateCommitMessage returns the mock response or error.
+func (m *MockAIExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	if m.MockError != nil {
+		return "", m.MockError
+	}
+	return m.MockResponse, nil
+}
+
+// Complete returns the mock response or error.
+func (m *MockAIExecutor) Complete(ctx context.Context, prompt string) (string, error) {
 	if m.MockError != nil {
 		return "", m.MockError
 	}
@@ -64,7 +76,7 @@ func TestGenerateCommitMessage(t *testing.T) {
 				MockError:    tt.mockError,
 			}
 
-			message, err := generateCommitMessage(executor, "fake diff")
+			message, err := executor.GenerateCommitMessage(context.Background(), "fake diff")
 
 			if tt.wantError {
 				if err == nil {
@@ -105,70 +117,35 @@ func TestGenerateCommitMessage(t *testing.T) {
 	}
 }
 
-func TestGitCommit(t *testing.T) {
-	tempDir := t.TempDir()
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.Chdir(originalDir)
-
-	if err := os.Chdir(tempDir); err != nil {
-		t.Fatal(err)
-	}
-
-	cmd := exec.Command("git", "init")
-	if err := cmd.Run(); err != nil {
-		t.Fatalf("Failed to initialize git repo: %v", err)
-	}
-
-	cmd = exec.Command("git", "config", "user.email", "test@example.com")
-	if err := cmd.Run(); err != nil {
-		t.Fatalf("Failed to set git user.email: %v", err)
-	}
-
-	cmd = exec.Command("git", "config", "user.name", "Test User")
-	if err := cmd.Run(); err != nil {
-		t.Fatalf("Failed to set git user.name: %v", err)
-	}
-
-	testFile := "test.txt"
-	if err := os.WriteFile(testFile, []byte("test content"), 0644); err != nil {
-		t.Fatal(err)
-	}
-
-	cmd = exec.Command("git", "add", testFile)
-	if err := cmd.Run(); err != nil {
-		t.Fatalf("Failed to add file: %v", err)
-	}
-
-	err = gitCommit("test: テストコミット")
-	if err != nil {
-		t.Errorf("gitCommit() error = %v", err)
-	}
+// fakeGitClient is a gitClient that returns canned responses for main()'s
+// tests, replacing the newGitClient package variable.
+type fakeGitClient struct {
+	diff       string
+	diffErr    error
+	commitErr  error
+	commitArgs string
+}
 
-	cmd = exec.Command("git", "log", "--oneline", "-1")
-	output, err := cmd.Output()
-	if err != nil {
-		t.Fatalf("Failed to get git log: %v", err)
-	}
+func (f *fakeGitClient) StagedDiff() (string, error) {
+	return f.diff, f.diffErr
+}
 
-	if !strings.Contains(string(output), "test: テストコミット") {
-		t.Errorf("Commit message not found in git log: %s", output)
-	}
+func (f *fakeGitClient) Commit(message string, opts git.CommitOpts) error {
+	f.commitArgs = message
+	return f.commitErr
 }
 
 func TestMainUserInput(t *testing.T) {
-	originalGetStagedDiff := getStagedDiff
-	getStagedDiff = func() (string, error) {
-		return "fake diff for main user input test", nil
+	originalNewGitClient := newGitClient
+	newGitClient = func() gitClient {
+		return &fakeGitClient{diff: "fake diff for main user input test"}
 	}
 	defer func() {
-		getStagedDiff = originalGetStagedDiff
+		newGitClient = originalNewGitClient
 	}()
 
 	originalNewExecutor := newExecutor
-	newExecutor = func(model string) (AIExecutor, error) {
+	newExecutor = func(model string, cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
 		return &MockAIExecutor{
 			MockResponse: "test: テスト用のコミットメッセージ",
 		}, nil
@@ -238,7 +215,7 @@ func TestMainUserInput(t *testing.T) {
 
 func TestMain_InvalidModel(t *testing.T) {
 	originalNewExecutor := newExecutor
-	newExecutor = func(model string) (AIExecutor, error) {
+	newExecutor = func(model string, cfg *config.Config, opts ExecutorOptions) (AIExecutor, error) {
 		return nil, fmt.Errorf("invalid model specified: %s", model)
 	}
 	defer func() {
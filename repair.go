@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shivase/gcauto/internal/commitmsg"
+)
+
+// repairPromptTemplate asks the executor to fix a commit message that failed
+// Conventional Commits validation, given the validation errors.
+const repairPromptTemplate = "The following commit message does not follow the Conventional Commits format and must be corrected:\n\n---\n%s\n---\n\nValidation problems:\n%s\n\nOutput only the corrected commit message, with no preamble or explanation."
+
+// repairCommitMessage validates message against the Conventional Commits
+// grammar, asking executor to fix it and re-validating up to maxRetries
+// times. It returns the first message that parses successfully, or the last
+// attempt along with the validation error if maxRetries is exhausted. A
+// negative maxRetries is treated as 0: the message is still validated once,
+// it just isn't sent back to the executor for repair.
+func repairCommitMessage(ctx context.Context, executor AIExecutor, message string, maxRetries int) (string, error) {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	var lastErr error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if _, err := commitmsg.Parse(message); err == nil {
+			return message, nil
+		} else {
+			lastErr = err
+		}
+
+		if attempt == maxRetries {
+			break
+		}
+
+		prompt := fmt.Sprintf(repairPromptTemplate, message, lastErr.Error())
+		fixed, err := executor.Complete(ctx, prompt)
+		if err != nil {
+			return message, fmt.Errorf("failed to repair commit message: %w", err)
+		}
+		message = fixed
+	}
+
+	return message, fmt.Errorf("commit message failed validation after %d attempt(s): %w", maxRetries+1, lastErr)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// sequencedExecutor returns each response in sequence, recording the prompts
+// it was called with.
+type sequencedExecutor struct {
+	responses []string
+	prompts   []string
+}
+
+func (e *sequencedExecutor) GenerateCommitMessage(ctx context.Context, diff string) (string, error) {
+	e.prompts = append(e.prompts, diff)
+	response := e.responses[len(e.prompts)-1]
+	return response, nil
+}
+
+// Complete records the prompt the same way GenerateCommitMessage does, since
+// repairCommitMessage calls the raw Complete method.
+func (e *sequencedExecutor) Complete(ctx context.Context, prompt string) (string, error) {
+	e.prompts = append(e.prompts, prompt)
+	response := e.responses[len(e.prompts)-1]
+	return response, nil
+}
+
+func TestRepairCommitMessage_ValidOnFirstTry(t *testing.T) {
+	executor := &sequencedExecutor{}
+	message, err := repairCommitMessage(context.Background(), executor, "feat: add login flow", 2)
+	if err != nil {
+		t.Fatalf("repairCommitMessage() unexpected error = %v", err)
+	}
+	if message != "feat: add login flow" {
+		t.Errorf("repairCommitMessage() = %q", message)
+	}
+	if len(executor.prompts) != 0 {
+		t.Errorf("repairCommitMessage() called the executor %d times, want 0", len(executor.prompts))
+	}
+}
+
+func TestRepairCommitMessage_FixesAfterRetry(t *testing.T) {
+	executor := &sequencedExecutor{responses: []string{"feat: add login flow"}}
+	message, err := repairCommitMessage(context.Background(), executor, "this is not conventional", 2)
+	if err != nil {
+		t.Fatalf("repairCommitMessage() unexpected error = %v", err)
+	}
+	if message != "feat: add login flow" {
+		t.Errorf("repairCommitMessage() = %q", message)
+	}
+	if len(executor.prompts) != 1 {
+		t.Fatalf("repairCommitMessage() called the executor %d times, want 1", len(executor.prompts))
+	}
+}
+
+func TestRepairCommitMessage_UsesCompleteNotGenerateCommitMessage(t *testing.T) {
+	executor := &methodTrackingExecutor{}
+	if _, err := repairCommitMessage(context.Background(), executor, "not conventional", 1); err == nil {
+		t.Fatal("repairCommitMessage() expected error since methodTrackingExecutor never returns a valid message")
+	}
+	if executor.generateCalls != 0 {
+		t.Errorf("repairCommitMessage() called GenerateCommitMessage %d times, want 0 (repair must use Complete)", executor.generateCalls)
+	}
+	if executor.completeCalls != 1 {
+		t.Errorf("repairCommitMessage() called Complete %d times, want 1", executor.completeCalls)
+	}
+}
+
+func TestRepairCommitMessage_NegativeMaxRetriesStillValidatesOnce(t *testing.T) {
+	executor := &sequencedExecutor{}
+	message, err := repairCommitMessage(context.Background(), executor, "feat: add login flow", -1)
+	if err != nil {
+		t.Fatalf("repairCommitMessage() unexpected error = %v", err)
+	}
+	if message != "feat: add login flow" {
+		t.Errorf("repairCommitMessage() = %q", message)
+	}
+}
+
+func TestRepairCommitMessage_ExhaustsRetries(t *testing.T) {
+	executor := &sequencedExecutor{responses: []string{"still not conventional", "still not conventional"}}
+	_, err := repairCommitMessage(context.Background(), executor, "not conventional", 2)
+	if err == nil {
+		t.Fatal("repairCommitMessage() expected error after exhausting retries, got none")
+	}
+	if len(executor.prompts) != 2 {
+		t.Errorf("repairCommitMessage() called the executor %d times, want 2", len(executor.prompts))
+	}
+}